@@ -0,0 +1,199 @@
+// Package auth provides JWT issuing/validation and a Gin middleware that
+// injects the authenticated User into the request context.
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role identifies what a user is allowed to see and change.
+type Role string
+
+const (
+	RoleStudent Role = "student"
+	RoleFaculty Role = "faculty"
+	RoleHOD     Role = "hod"
+	RoleAdmin   Role = "admin"
+)
+
+// AccessTokenTTL and RefreshTokenTTL control how long issued tokens are valid.
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// Token types distinguish access tokens (accepted by Middleware) from
+// refresh tokens (accepted only by Refresh), so a long-lived refresh token
+// can't be replayed as a Bearer access token.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// User is the identity injected into the request context by Middleware.
+// Dept and FacultyID scope what a non-admin user can see or modify.
+type User struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Role      Role   `json:"role"`
+	Dept      string `json:"dept"`
+	FacultyID string `json:"facultyid"`
+}
+
+// claims is the JWT payload used for both access and refresh tokens; Type
+// holds which one (TokenTypeAccess or TokenTypeRefresh) so ParseToken can
+// reject a token presented as the wrong kind.
+type claims struct {
+	jwt.RegisteredClaims
+	UserID    int    `json:"uid"`
+	Username  string `json:"username"`
+	Role      Role   `json:"role"`
+	Dept      string `json:"dept"`
+	FacultyID string `json:"facultyid"`
+	Type      string `json:"typ"`
+}
+
+// GenerateToken signs a JWT of the given tokenType (TokenTypeAccess or
+// TokenTypeRefresh) carrying the user's identity and role.
+func GenerateToken(user User, secret []byte, ttl time.Duration, tokenType string) (string, error) {
+	now := time.Now()
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		UserID:    user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		Dept:      user.Dept,
+		FacultyID: user.FacultyID,
+		Type:      tokenType,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
+	return token.SignedString(secret)
+}
+
+// ParseToken validates the signature, expiry, and type of tokenStr and
+// returns the User it encodes. tokenStr is rejected unless its "typ" claim
+// matches expectedType.
+func ParseToken(tokenStr string, secret []byte, expectedType string) (*User, error) {
+	var c claims
+	token, err := jwt.ParseWithClaims(tokenStr, &c, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if c.Type != expectedType {
+		return nil, errors.New("unexpected token type")
+	}
+	return &User{
+		ID:        c.UserID,
+		Username:  c.Username,
+		Role:      c.Role,
+		Dept:      c.Dept,
+		FacultyID: c.FacultyID,
+	}, nil
+}
+
+// HashPassword returns the bcrypt hash of password for storage in the users table.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword reports whether password matches the bcrypt hash stored for the user.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Middleware validates the Authorization header's bearer token and injects
+// the resulting *User into the Gin context under contextUserKey. Requests
+// without a valid token are aborted with 401.
+func Middleware(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing Authorization header"})
+			return
+		}
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header must be a bearer token"})
+			return
+		}
+		user, err := ParseToken(parts[1], secret, TokenTypeAccess)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token: " + err.Error()})
+			return
+		}
+		c.Set(contextUserKey, user)
+		c.Next()
+	}
+}
+
+// WSMiddleware validates the access token carried in the "token" query
+// parameter and injects the resulting *User into the Gin context. Browsers'
+// native WebSocket API cannot set an Authorization header on the handshake
+// request, so the websocket route authenticates via query param instead of
+// Middleware's bearer header. Requests without a valid token are aborted
+// with 401 before the upgrade happens.
+func WSMiddleware(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing token query parameter"})
+			return
+		}
+		user, err := ParseToken(token, secret, TokenTypeAccess)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token: " + err.Error()})
+			return
+		}
+		c.Set(contextUserKey, user)
+		c.Next()
+	}
+}
+
+const contextUserKey = "auth_user"
+
+// UserFromContext retrieves the *User set by Middleware.
+func UserFromContext(c *gin.Context) (*User, bool) {
+	v, ok := c.Get(contextUserKey)
+	if !ok {
+		return nil, false
+	}
+	user, ok := v.(*User)
+	return user, ok
+}
+
+// RequireRole aborts with 403 unless the authenticated user has one of the given roles.
+func RequireRole(roles ...Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := UserFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+			return
+		}
+		for _, r := range roles {
+			if user.Role == r {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	}
+}
@@ -0,0 +1,54 @@
+package auth
+
+import "testing"
+
+var testUser = User{ID: 1, Username: "alice", Role: RoleFaculty, Dept: "CSE", FacultyID: "F1"}
+var testSecret = []byte("test-secret")
+
+func TestParseTokenRoundTripsAccessToken(t *testing.T) {
+	token, err := GenerateToken(testUser, testSecret, AccessTokenTTL, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	user, err := ParseToken(token, testSecret, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if user.ID != testUser.ID || user.Username != testUser.Username || user.Role != testUser.Role {
+		t.Errorf("ParseToken returned %+v, want %+v", user, testUser)
+	}
+}
+
+func TestParseTokenRejectsRefreshTokenAsAccess(t *testing.T) {
+	refreshToken, err := GenerateToken(testUser, testSecret, RefreshTokenTTL, TokenTypeRefresh)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	if _, err := ParseToken(refreshToken, testSecret, TokenTypeAccess); err == nil {
+		t.Fatal("expected a refresh token presented as an access token to be rejected")
+	}
+}
+
+func TestParseTokenRejectsAccessTokenAsRefresh(t *testing.T) {
+	accessToken, err := GenerateToken(testUser, testSecret, AccessTokenTTL, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	if _, err := ParseToken(accessToken, testSecret, TokenTypeRefresh); err == nil {
+		t.Fatal("expected an access token presented as a refresh token to be rejected")
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	token, err := GenerateToken(testUser, testSecret, AccessTokenTTL, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	if _, err := ParseToken(token, []byte("wrong-secret"), TokenTypeAccess); err == nil {
+		t.Fatal("expected a token signed with a different secret to be rejected")
+	}
+}
@@ -0,0 +1,140 @@
+// Package db owns the MySQL connection and the persistence-layer helpers
+// shared across handlers: connecting, syncing course_details, and recording
+// audit entries.
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"course-upload/go_server/models"
+)
+
+// DB is the process-wide MySQL connection pool. Connect must be called before use.
+var DB *sql.DB
+
+// Connect opens the MySQL connection described by the DB_* environment
+// variables and stores it in DB.
+func Connect() error {
+	dbUser := os.Getenv("DB_USER")
+	dbPassword := os.Getenv("DB_PASSWORD")
+	dbHost := os.Getenv("DB_HOST")
+	dbPort := os.Getenv("DB_PORT")
+	dbName := os.Getenv("DB_NAME")
+	if dbUser == "" || dbPassword == "" || dbHost == "" || dbPort == "" || dbName == "" {
+		return fmt.Errorf("database environment variables not set")
+	}
+
+	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", dbUser, dbPassword, dbHost, dbPort, dbName)
+	conn, err := sql.Open("mysql", dataSourceName)
+	if err != nil {
+		return fmt.Errorf("error opening database: %w", err)
+	}
+	if err := conn.Ping(); err != nil {
+		return fmt.Errorf("error connecting to the database: %w", err)
+	}
+
+	DB = conn
+	return nil
+}
+
+// users table (created out-of-band via migration):
+//
+//	CREATE TABLE users (
+//	    id INT AUTO_INCREMENT PRIMARY KEY,
+//	    username VARCHAR(100) NOT NULL UNIQUE,
+//	    password_hash VARCHAR(255) NOT NULL,
+//	    role VARCHAR(20) NOT NULL,
+//	    dept VARCHAR(50),
+//	    facultyid VARCHAR(50)
+//	);
+
+// course_audit table (created out-of-band via migration):
+//
+//	CREATE TABLE course_audit (
+//	    id INT AUTO_INCREMENT PRIMARY KEY,
+//	    course_id INT NOT NULL,
+//	    action VARCHAR(20) NOT NULL,
+//	    actor_id INT,
+//	    before_data JSON,
+//	    after_data JSON,
+//	    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+//	);
+
+// UpsertCourseQuery is the insert used by every course-ingestion path
+// (JSON upload and CSV/XLSX file upload) so they stay consistent.
+const UpsertCourseQuery = `
+	INSERT INTO courses (
+		dept, semester, coursetype, coursecode, coursename,
+		coursenature, facultyid, regulation, degree, academicyear, hodapproval, status
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		dept = VALUES(dept),
+		coursename = VALUES(coursename),
+		facultyid = VALUES(facultyid),
+        hodapproval = VALUES(hodapproval),
+		status = VALUES(status)
+`
+
+// RecordAudit inserts a course_audit row capturing a mutation's before/after
+// state, so it can later be inspected or reverted. It must be called inside
+// the same transaction as the mutation it records.
+func RecordAudit(tx *sql.Tx, action string, courseID int, userID int, before, after models.Course) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO course_audit (course_id, action, actor_id, before_data, after_data) VALUES (?, ?, ?, ?, ?)",
+		courseID, action, userID, beforeJSON, afterJSON,
+	)
+	return err
+}
+
+// FetchCourseByID reads the full current row for a course, for use as the
+// "before" snapshot of an audit entry.
+func FetchCourseByID(tx *sql.Tx, id int) (models.Course, error) {
+	var course models.Course
+	err := tx.QueryRow(
+		`SELECT id, dept, semester, coursetype, coursecode, coursename, coursenature,
+			facultyid, regulation, degree, academicyear, hodapproval, status
+		FROM courses WHERE id = ?`, id,
+	).Scan(
+		&course.ID, &course.Dept, &course.Semester, &course.CourseType,
+		&course.CourseCode, &course.CourseName, &course.CourseNature,
+		&course.FacultyID, &course.Regulation, &course.Degree, &course.AcademicYear,
+		&course.HodApproval, &course.Status,
+	)
+	return course, err
+}
+
+// FetchCourseByNaturalKey reads the row matching course's
+// coursecode/semester/regulation/degree/academicyear unique key, i.e. the
+// row UpsertCourseQuery would update instead of insert. Callers use this
+// before the upsert to tell whether it's about to create or update a row,
+// and to capture the real "before" snapshot for the latter. Returns
+// sql.ErrNoRows if no such row exists yet.
+func FetchCourseByNaturalKey(tx *sql.Tx, course models.Course) (models.Course, error) {
+	var existing models.Course
+	err := tx.QueryRow(
+		`SELECT id, dept, semester, coursetype, coursecode, coursename, coursenature,
+			facultyid, regulation, degree, academicyear, hodapproval, status
+		FROM courses
+		WHERE coursecode = ? AND semester = ? AND regulation = ? AND degree = ? AND academicyear = ?`,
+		course.CourseCode, course.Semester, course.Regulation, course.Degree, course.AcademicYear,
+	).Scan(
+		&existing.ID, &existing.Dept, &existing.Semester, &existing.CourseType,
+		&existing.CourseCode, &existing.CourseName, &existing.CourseNature,
+		&existing.FacultyID, &existing.Regulation, &existing.Degree, &existing.AcademicYear,
+		&existing.HodApproval, &existing.Status,
+	)
+	return existing, err
+}
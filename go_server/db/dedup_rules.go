@@ -0,0 +1,176 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"course-upload/go_server/models"
+)
+
+// course_dedup_rules table (created out-of-band via migration):
+//
+//	CREATE TABLE course_dedup_rules (
+//	    id INT AUTO_INCREMENT PRIMARY KEY,
+//	    regulation VARCHAR(50) NOT NULL DEFAULT '*',
+//	    degree VARCHAR(50) NOT NULL DEFAULT '*',
+//	    nature_pattern VARCHAR(100) NOT NULL,
+//	    priority INT NOT NULL,
+//	    UNIQUE KEY regulation_degree_pattern (regulation, degree, nature_pattern)
+//	);
+
+// defaultDedupRules is the ranking SyncCourseDetails falls back to when
+// course_dedup_rules is empty, preserving the original
+// theory & lab > theory > lab behavior for every regulation/degree.
+var defaultDedupRules = []models.DedupRule{
+	{Regulation: "*", Degree: "*", NaturePattern: "theory & lab", Priority: 1},
+	{Regulation: "*", Degree: "*", NaturePattern: "theory with lab", Priority: 1},
+	{Regulation: "*", Degree: "*", NaturePattern: "theory", Priority: 2},
+	{Regulation: "*", Degree: "*", NaturePattern: "lab", Priority: 3},
+}
+
+// ListDedupRules returns every configured dedup rule, ordered by priority.
+func ListDedupRules() ([]models.DedupRule, error) {
+	rows, err := DB.Query("SELECT id, regulation, degree, nature_pattern, priority FROM course_dedup_rules ORDER BY priority, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []models.DedupRule{}
+	for rows.Next() {
+		var rule models.DedupRule
+		if err := rows.Scan(&rule.ID, &rule.Regulation, &rule.Degree, &rule.NaturePattern, &rule.Priority); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// ReplaceDedupRules atomically replaces every configured dedup rule with the given set.
+func ReplaceDedupRules(rules []models.DedupRule) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM course_dedup_rules"); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO course_dedup_rules (regulation, degree, nature_pattern, priority) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, rule := range rules {
+		if _, err := stmt.Exec(rule.Regulation, rule.Degree, rule.NaturePattern, rule.Priority); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// dedupRulesForSync loads the configured dedup rules, falling back to
+// defaultDedupRules when course_dedup_rules has no rows.
+func dedupRulesForSync(tx *sql.Tx) ([]models.DedupRule, error) {
+	rows, err := tx.Query("SELECT regulation, degree, nature_pattern, priority FROM course_dedup_rules ORDER BY priority, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.DedupRule
+	for rows.Next() {
+		var rule models.DedupRule
+		if err := rows.Scan(&rule.Regulation, &rule.Degree, &rule.NaturePattern, &rule.Priority); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return defaultDedupRules, nil
+	}
+	return rules, nil
+}
+
+// buildSyncCourseDetailsQuery emits the INSERT ... WITH RankedCourses query
+// that ranks courses within a coursecode/semester/regulation/degree/
+// academicyear group: each rule contributes a WHEN clause matching its
+// regulation ("*" matches any), degree ("*" matches any), and nature_pattern
+// (a case-insensitive LIKE pattern); the lowest matching Priority wins the group.
+func buildSyncCourseDetailsQuery(rules []models.DedupRule) (string, []interface{}) {
+	var caseExpr strings.Builder
+	var args []interface{}
+	caseExpr.WriteString("CASE\n")
+	for _, rule := range rules {
+		conditions := []string{"LOWER(coursenature) LIKE LOWER(?)"}
+		args = append(args, rule.NaturePattern)
+		if rule.Regulation != "*" {
+			conditions = append(conditions, "regulation = ?")
+			args = append(args, rule.Regulation)
+		}
+		if rule.Degree != "*" {
+			conditions = append(conditions, "degree = ?")
+			args = append(args, rule.Degree)
+		}
+		fmt.Fprintf(&caseExpr, "    WHEN %s THEN %d\n", strings.Join(conditions, " AND "), rule.Priority)
+	}
+	caseExpr.WriteString("    ELSE 999\nEND")
+
+	query := fmt.Sprintf(`
+INSERT INTO course_details (id, dept, semester, coursetype, coursecode, coursename, coursenature, regulation, degree, academicyear)
+WITH RankedCourses AS (
+    SELECT
+        id, dept, semester, coursetype, coursecode, coursename, coursenature, regulation, degree, academicyear,
+        ROW_NUMBER() OVER(
+            PARTITION BY coursecode, semester, regulation, degree, academicyear
+            ORDER BY %s, id
+        ) as rn
+    FROM
+        courses
+    WHERE status = 1
+)
+SELECT
+    id, dept, semester, coursetype, coursecode, coursename, coursenature, regulation, degree, academicyear
+FROM
+    RankedCourses
+WHERE
+    rn = 1
+ON DUPLICATE KEY UPDATE
+    id = VALUES(id),
+    dept = VALUES(dept),
+    semester = VALUES(semester),
+    coursetype = VALUES(coursetype),
+    coursename = VALUES(coursename),
+    coursenature = VALUES(coursenature),
+    regulation = VALUES(regulation),
+    degree = VALUES(degree),
+    academicyear = VALUES(academicyear);
+`, caseExpr.String())
+
+	return query, args
+}
+
+// SyncCourseDetails executes the synchronization logic within a transaction,
+// ranking courses within each group according to the dedup rules configured
+// in course_dedup_rules (or defaultDedupRules if none are configured).
+func SyncCourseDetails(tx *sql.Tx) error {
+	if _, err := tx.Exec("DELETE FROM course_details"); err != nil {
+		return err
+	}
+	rules, err := dedupRulesForSync(tx)
+	if err != nil {
+		return err
+	}
+	query, args := buildSyncCourseDetailsQuery(rules)
+	_, err = tx.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"course-upload/go_server/models"
+)
+
+func TestBuildSyncCourseDetailsQueryWildcardRule(t *testing.T) {
+	rules := []models.DedupRule{
+		{Regulation: "*", Degree: "*", NaturePattern: "lab", Priority: 3},
+	}
+
+	query, args := buildSyncCourseDetailsQuery(rules)
+
+	if !strings.Contains(query, "WHEN LOWER(coursenature) LIKE LOWER(?) THEN 3") {
+		t.Errorf("expected a bare nature-pattern WHEN clause for a wildcard rule, got:\n%s", query)
+	}
+	if strings.Contains(query, "regulation = ?") || strings.Contains(query, "degree = ?") {
+		t.Errorf("wildcard regulation/degree must not add extra conditions, got:\n%s", query)
+	}
+	if len(args) != 1 || args[0] != "lab" {
+		t.Errorf("expected args [\"lab\"], got %v", args)
+	}
+}
+
+func TestBuildSyncCourseDetailsQueryScopedRule(t *testing.T) {
+	rules := []models.DedupRule{
+		{Regulation: "R2021", Degree: "BE", NaturePattern: "theory & lab", Priority: 1},
+	}
+
+	query, args := buildSyncCourseDetailsQuery(rules)
+
+	if !strings.Contains(query, "LOWER(coursenature) LIKE LOWER(?) AND regulation = ? AND degree = ? THEN 1") {
+		t.Errorf("expected nature/regulation/degree conditions in order, got:\n%s", query)
+	}
+	if len(args) != 3 || args[0] != "theory & lab" || args[1] != "R2021" || args[2] != "BE" {
+		t.Errorf("expected args in [nature_pattern, regulation, degree] order, got %v", args)
+	}
+}
+
+func TestBuildSyncCourseDetailsQueryFallsBackToElse999(t *testing.T) {
+	query, _ := buildSyncCourseDetailsQuery(defaultDedupRules)
+
+	if !strings.Contains(query, "ELSE 999") {
+		t.Errorf("expected an ELSE 999 fallback for courses matching no rule, got:\n%s", query)
+	}
+	if strings.Count(query, "WHEN") != len(defaultDedupRules) {
+		t.Errorf("expected one WHEN clause per rule, got:\n%s", query)
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"course-upload/go_server/db"
+	"course-upload/go_server/models"
+)
+
+// GetDedupRules godoc
+//
+//	@Summary		List course-nature dedup rules
+//	@Description	Returns the rules used to rank courses within a coursecode/semester/regulation/degree/academicyear group when syncing course_details.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}	models.DedupRule
+//	@Router			/api/admin/dedup-rules [get]
+func GetDedupRules(c *gin.Context) {
+	rules, err := db.ListDedupRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dedup rules: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// UpdateDedupRules godoc
+//
+//	@Summary		Replace course-nature dedup rules
+//	@Description	Atomically replaces every configured dedup rule. Call POST /api/admin/resync afterwards to apply the new ranking to course_details.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		[]models.DedupRuleRequest	true	"Replacement rule set"
+//	@Success		200		{object}	map[string]string
+//	@Router			/api/admin/dedup-rules [put]
+func UpdateDedupRules(c *gin.Context) {
+	var requests []models.DedupRuleRequest
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dedup rule data provided: " + err.Error()})
+		return
+	}
+
+	rules := make([]models.DedupRule, len(requests))
+	for i, req := range requests {
+		rules[i] = req.ToDedupRule()
+	}
+
+	if err := db.ReplaceDedupRules(rules); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save dedup rules: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dedup rules updated successfully"})
+}
+
+// ResyncCourseDetails godoc
+//
+//	@Summary		Re-run course_details sync
+//	@Description	Runs the course_details dedup/sync on demand, using the currently configured dedup rules.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	map[string]string
+//	@Router			/api/admin/resync [post]
+func ResyncCourseDetails(c *gin.Context) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction: " + err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to synchronize course details: " + err.Error()})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Course details re-synchronized successfully"})
+}
@@ -0,0 +1,123 @@
+// Package handlers implements the HTTP handlers for the course API: auth,
+// course CRUD, bulk upload, audit history, and the live-update websocket.
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"course-upload/go_server/auth"
+	"course-upload/go_server/db"
+)
+
+// jwtSecret signs and validates all access/refresh tokens issued by Login.
+var jwtSecret []byte
+
+// SetJWTSecret configures the secret used to sign and validate JWTs. It must
+// be called once during startup before the router is wired up.
+func SetJWTSecret(secret []byte) {
+	jwtSecret = secret
+}
+
+// loginRequest is the body expected by POST /api/login.
+type loginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// loginResponse carries the token pair handed back on a successful login.
+type loginResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// refreshRequest is the body expected by POST /api/refresh.
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Login godoc
+//
+//	@Summary		Log in
+//	@Description	Verifies credentials against the users table and issues an access/refresh token pair.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		loginRequest	true	"Login credentials"
+//	@Success		200		{object}	loginResponse
+//	@Failure		401		{object}	map[string]string
+//	@Router			/api/login [post]
+func Login(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid login payload: " + err.Error()})
+		return
+	}
+
+	var user auth.User
+	var passwordHash string
+	err := db.DB.QueryRow(
+		"SELECT id, username, password_hash, role, dept, facultyid FROM users WHERE username = ?",
+		req.Username,
+	).Scan(&user.ID, &user.Username, &passwordHash, &user.Role, &user.Dept, &user.FacultyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up user: " + err.Error()})
+		return
+	}
+
+	if !auth.CheckPassword(passwordHash, req.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
+
+	accessToken, err := auth.GenerateToken(user, jwtSecret, auth.AccessTokenTTL, auth.TokenTypeAccess)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue access token: " + err.Error()})
+		return
+	}
+	refreshToken, err := auth.GenerateToken(user, jwtSecret, auth.RefreshTokenTTL, auth.TokenTypeRefresh)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue refresh token: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponse{AccessToken: accessToken, RefreshToken: refreshToken})
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh an access token
+//	@Description	Exchanges a still-valid refresh token for a new access token.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		refreshRequest	true	"Refresh token"
+//	@Success		200		{object}	loginResponse
+//	@Failure		401		{object}	map[string]string
+//	@Router			/api/refresh [post]
+func Refresh(c *gin.Context) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid refresh payload: " + err.Error()})
+		return
+	}
+
+	user, err := auth.ParseToken(req.RefreshToken, jwtSecret, auth.TokenTypeRefresh)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token: " + err.Error()})
+		return
+	}
+
+	accessToken, err := auth.GenerateToken(*user, jwtSecret, auth.AccessTokenTTL, auth.TokenTypeAccess)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue access token: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, loginResponse{AccessToken: accessToken, RefreshToken: req.RefreshToken})
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"course-upload/go_server/auth"
+	"course-upload/go_server/db"
+	"course-upload/go_server/models"
+)
+
+// filterableColumns whitelists the equality filters GetCourses accepts as
+// query params, so filter column names are never built from raw user input.
+var filterableColumns = []string{"dept", "semester", "regulation", "degree", "academicyear", "facultyid", "hodapproval"}
+
+// sortableColumns whitelists the columns GetCourses may sort by.
+var sortableColumns = map[string]bool{
+	"id": true, "dept": true, "semester": true, "coursecode": true, "coursename": true,
+	"coursenature": true, "facultyid": true, "regulation": true, "degree": true,
+	"academicyear": true, "hodapproval": true,
+}
+
+const defaultCourseLimit = 50
+
+// buildCourseWhere assembles the WHERE clause and matching args for
+// GetCourses from the request's filter/search query params plus the
+// requesting user's dept/facultyid scope.
+func buildCourseWhere(c *gin.Context) (string, []interface{}) {
+	conditions := []string{"status = 1"}
+	args := []interface{}{}
+
+	for _, column := range filterableColumns {
+		if value := c.Query(column); value != "" {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	if search := c.Query("search"); search != "" {
+		conditions = append(conditions, "(coursename LIKE ? OR coursecode LIKE ?)")
+		like := "%" + search + "%"
+		args = append(args, like, like)
+	}
+
+	if user, ok := auth.UserFromContext(c); ok {
+		if column, value, restricted := scopeFilter(user); restricted {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// GetCourses godoc
+//
+//	@Summary		List courses
+//	@Description	Lists active courses with pagination, whitelisted filtering/sorting, and free-text search over coursename/coursecode. Non-admin users only see courses within their own department/faculty scope.
+//	@Tags			courses
+//	@Produce		json
+//	@Param			limit		query		int		false	"Max rows to return"
+//	@Param			offset		query		int		false	"Rows to skip"
+//	@Param			sort_column	query		string	false	"Column to sort by"
+//	@Param			sort_order	query		string	false	"asc or desc"
+//	@Param			search		query		string	false	"Matches coursename or coursecode"
+//	@Success		200			{object}	map[string]interface{}
+//	@Router			/api/courses [get]
+func GetCourses(c *gin.Context) {
+	where, args := buildCourseWhere(c)
+
+	limit := defaultCourseLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v >= 0 {
+		offset = v
+	}
+
+	sortColumn := "id"
+	if v := c.Query("sort_column"); sortableColumns[v] {
+		sortColumn = v
+	}
+	sortOrder := "ASC"
+	if strings.ToUpper(c.Query("sort_order")) == "DESC" {
+		sortOrder = "DESC"
+	}
+
+	query := fmt.Sprintf(
+		`SELECT id, dept, semester, coursetype, coursecode, coursename, coursenature,
+			facultyid, regulation, degree, academicyear, hodapproval, status
+		FROM courses WHERE %s ORDER BY %s %s LIMIT ? OFFSET ?`,
+		where, sortColumn, sortOrder,
+	)
+	rows, err := db.DB.Query(query, append(append([]interface{}{}, args...), limit, offset)...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to fetch course data", "error": err.Error()})
+		return
+	}
+	defer rows.Close()
+	courses := []models.CourseResponse{}
+	for rows.Next() {
+		var course models.Course
+		err := rows.Scan(
+			&course.ID, &course.Dept, &course.Semester, &course.CourseType,
+			&course.CourseCode, &course.CourseName, &course.CourseNature,
+			&course.FacultyID, &course.Regulation, &course.Degree, &course.AcademicYear, &course.HodApproval, &course.Status,
+		)
+		if err != nil {
+			log.Printf("Error scanning course row: %v", err)
+			continue
+		}
+		courses = append(courses, models.NewCourseResponse(course))
+	}
+	if err = rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error iterating through course data", "error": err.Error()})
+		return
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM courses WHERE %s", where)
+	if err := db.DB.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to count course data", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": courses, "total": total, "limit": limit, "offset": offset})
+}
+
+// UpdateCourse godoc
+//
+//	@Summary		Update a course
+//	@Description	Updates a course's own fields and propagates the shared fields (coursecode, coursename, dept) to every course in the same coursecode/semester/regulation/degree/academicyear group that falls within the requesting user's dept/facultyid scope.
+//	@Tags			courses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Course ID"
+//	@Param			request	body		models.CourseUpdateRequest	true	"Updated course fields"
+//	@Success		200		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Router			/api/courses/{id} [put]
+func UpdateCourse(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format: " + err.Error()})
+		return
+	}
+
+	if user, ok := auth.UserFromContext(c); ok {
+		inScope, err := courseInScope(id, user)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify course scope: " + err.Error()})
+			return
+		}
+		if !inScope {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this course"})
+			return
+		}
+	}
+
+	var req models.CourseUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data provided: " + err.Error()})
+		return
+	}
+	updatedCourseData := req.ToCourse(id)
+	if fieldErrs := models.ValidateCourse(updatedCourseData); len(fieldErrs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course data", "fields": fieldErrs})
+		return
+	}
+	updatedCourseData.CourseNature = models.NormalizeCourseNature(updatedCourseData.CourseNature)
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction: " + err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	beforeCourse, err := db.FetchCourseByID(tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load original course: " + err.Error()})
+		return
+	}
+
+	var groupIdentifier models.Course
+	err = tx.QueryRow(`
+		SELECT coursecode, semester, regulation, degree, academicyear
+		FROM courses WHERE id = ?`, id).Scan(
+		&groupIdentifier.CourseCode, &groupIdentifier.Semester, &groupIdentifier.Regulation,
+		&groupIdentifier.Degree, &groupIdentifier.AcademicYear,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find original course group: " + err.Error()})
+		return
+	}
+
+	groupWhere := "coursecode = ? AND semester = ? AND regulation = ? AND degree = ? AND academicyear = ?"
+	groupArgs := []interface{}{
+		groupIdentifier.CourseCode, groupIdentifier.Semester, groupIdentifier.Regulation,
+		groupIdentifier.Degree, groupIdentifier.AcademicYear,
+	}
+	if user, ok := auth.UserFromContext(c); ok {
+		if column, value, restricted := scopeFilter(user); restricted {
+			groupWhere += " AND " + column + " = ?"
+			groupArgs = append(groupArgs, value)
+		}
+	}
+
+	stmt, err := tx.Prepare(`
+        UPDATE courses SET
+            coursecode = ?, coursename = ?, dept = ?
+        WHERE ` + groupWhere)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare group update statement: " + err.Error()})
+		return
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		append([]interface{}{updatedCourseData.CourseCode, updatedCourseData.CourseName, updatedCourseData.Dept}, groupArgs...)...,
+	)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute group update: " + err.Error()})
+		return
+	}
+
+	singleUpdateStmt, err := tx.Prepare(`
+		UPDATE courses SET
+			coursenature = ?, facultyid = ?, hodapproval = ?, coursetype = ?,
+            semester = ?, regulation = ?, degree = ?, academicyear = ?
+		WHERE id = ?
+	`)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare single row update statement: " + err.Error()})
+		return
+	}
+	defer singleUpdateStmt.Close()
+
+	_, err = singleUpdateStmt.Exec(
+		updatedCourseData.CourseNature, updatedCourseData.FacultyID, updatedCourseData.HodApproval,
+		updatedCourseData.CourseType, updatedCourseData.Semester, updatedCourseData.Regulation,
+		updatedCourseData.Degree, updatedCourseData.AcademicYear, id,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute single row update: " + err.Error()})
+		return
+	}
+
+	afterCourse, err := db.FetchCourseByID(tx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load updated course: " + err.Error()})
+		return
+	}
+	if err := db.RecordAudit(tx, "update", id, actorID(c), beforeCourse, afterCourse); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record audit entry: " + err.Error()})
+		return
+	}
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to re-synchronize course details", "error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
+		return
+	}
+
+	eventBus.Publish(models.CourseEvent{Type: "updated", Course: afterCourse})
+
+	c.JSON(http.StatusOK, gin.H{"message": "Course group and individual course details updated successfully"})
+}
+
+// DeleteCourse godoc
+//
+//	@Summary		Soft-delete a course
+//	@Description	Sets a course's status to 0 rather than removing the row.
+//	@Tags			courses
+//	@Produce		json
+//	@Param			id	path		int	true	"Course ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Router			/api/courses/{id} [delete]
+func DeleteCourse(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+
+	if user, ok := auth.UserFromContext(c); ok {
+		inScope, err := courseInScope(id, user)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify course scope: " + err.Error()})
+			return
+		}
+		if !inScope {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this course"})
+			return
+		}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction: " + err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	beforeCourse, err := db.FetchCourseByID(tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load original course: " + err.Error()})
+		return
+	}
+
+	stmt, err := tx.Prepare("UPDATE courses SET status = 0 WHERE id = ?")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to prepare update statement for soft delete: " + err.Error()})
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute soft delete: " + err.Error()})
+		return
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+		return
+	}
+
+	afterCourse := beforeCourse
+	afterCourse.Status = 0
+	if err := db.RecordAudit(tx, "delete", id, actorID(c), beforeCourse, afterCourse); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record audit entry: " + err.Error()})
+		return
+	}
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to re-synchronize course details after soft deletion", "error": err.Error()})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
+		return
+	}
+
+	eventBus.Publish(models.CourseEvent{Type: "deleted", Course: afterCourse})
+
+	c.JSON(http.StatusOK, gin.H{"message": "Course soft-deleted successfully"})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func contextWithQuery(t *testing.T, rawQuery string) *gin.Context {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	req := httptest.NewRequest("GET", "/api/courses?"+rawQuery, nil)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return c
+}
+
+func TestBuildCourseWhereOnlyAppliesWhitelistedFilters(t *testing.T) {
+	c := contextWithQuery(t, "dept=CSE&coursecode=CS101&status=0")
+
+	where, args := buildCourseWhere(c)
+
+	if !strings.Contains(where, "dept = ?") {
+		t.Errorf("expected dept filter in WHERE clause, got %q", where)
+	}
+	if strings.Contains(where, "coursecode = ?") {
+		t.Errorf("coursecode is not in filterableColumns and must not appear in WHERE clause, got %q", where)
+	}
+	if strings.Count(where, "status") != 1 {
+		t.Errorf("the hardcoded status = 1 condition must not be overridable by a status query param, got %q", where)
+	}
+	if len(args) != 1 || args[0] != "CSE" {
+		t.Errorf("expected args [\"CSE\"], got %v", args)
+	}
+}
+
+func TestBuildCourseWhereAppliesSearch(t *testing.T) {
+	c := contextWithQuery(t, "search=algo")
+
+	where, args := buildCourseWhere(c)
+
+	if !strings.Contains(where, "coursename LIKE ?") || !strings.Contains(where, "coursecode LIKE ?") {
+		t.Errorf("expected a LIKE condition over coursename and coursecode, got %q", where)
+	}
+	if len(args) != 2 || args[0] != "%algo%" || args[1] != "%algo%" {
+		t.Errorf("expected both LIKE args wrapped in %%, got %v", args)
+	}
+}
+
+func TestSortableColumnsWhitelist(t *testing.T) {
+	if sortableColumns["1; DROP TABLE courses"] {
+		t.Fatal("sortableColumns must not accept arbitrary SQL")
+	}
+	if !sortableColumns["coursecode"] {
+		t.Fatal("expected coursecode to be a sortable column")
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"course-upload/go_server/auth"
+	"course-upload/go_server/db"
+	"course-upload/go_server/models"
+)
+
+// GetCourseHistory godoc
+//
+//	@Summary		Get a course's audit history
+//	@Description	Returns the ordered list of audit entries recorded for a course.
+//	@Tags			courses
+//	@Produce		json
+//	@Param			id	path		int	true	"Course ID"
+//	@Success		200	{array}		models.CourseAuditEntry
+//	@Router			/api/courses/{id}/history [get]
+func GetCourseHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+
+	if user, ok := auth.UserFromContext(c); ok {
+		inScope, err := courseInScope(id, user)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify course scope: " + err.Error()})
+			return
+		}
+		if !inScope {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this course"})
+			return
+		}
+	}
+
+	rows, err := db.DB.Query(
+		`SELECT id, course_id, action, actor_id, before_data, after_data, created_at
+		FROM course_audit WHERE course_id = ? ORDER BY created_at ASC, id ASC`, id,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch course history: " + err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	history := []models.CourseAuditEntry{}
+	for rows.Next() {
+		var entry models.CourseAuditEntry
+		var beforeJSON, afterJSON []byte
+		if err := rows.Scan(&entry.ID, &entry.CourseID, &entry.Action, &entry.ActorID, &beforeJSON, &afterJSON, &entry.CreatedAt); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning audit row: " + err.Error()})
+			return
+		}
+		if err := json.Unmarshal(beforeJSON, &entry.Before); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error decoding audit snapshot: " + err.Error()})
+			return
+		}
+		if err := json.Unmarshal(afterJSON, &entry.After); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error decoding audit snapshot: " + err.Error()})
+			return
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error iterating through audit history: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// RevertCourse godoc
+//
+//	@Summary		Revert a course to a prior audit snapshot
+//	@Description	Re-applies the "before" snapshot of a past audit entry and re-runs the course_details sync, recording the revert itself as a new audit entry.
+//	@Tags			courses
+//	@Produce		json
+//	@Param			id		path		int	true	"Course ID"
+//	@Param			auditId	path		int	true	"Audit entry ID to revert to"
+//	@Success		200		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Router			/api/courses/{id}/revert/{auditId} [post]
+func RevertCourse(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID format"})
+		return
+	}
+	auditID, err := strconv.Atoi(c.Param("auditId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit ID format"})
+		return
+	}
+
+	if user, ok := auth.UserFromContext(c); ok {
+		inScope, err := courseInScope(id, user)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify course scope: " + err.Error()})
+			return
+		}
+		if !inScope {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have access to this course"})
+			return
+		}
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction: " + err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	var beforeJSON []byte
+	err = tx.QueryRow(
+		"SELECT before_data FROM course_audit WHERE id = ? AND course_id = ?", auditID, id,
+	).Scan(&beforeJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No audit entry found with the given ID for this course"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load audit entry: " + err.Error()})
+		return
+	}
+	var snapshot models.Course
+	if err := json.Unmarshal(beforeJSON, &snapshot); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decode audit snapshot: " + err.Error()})
+		return
+	}
+
+	current, err := db.FetchCourseByID(tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No course found with the given ID"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load current course: " + err.Error()})
+		return
+	}
+
+	_, err = tx.Exec(
+		`UPDATE courses SET
+			dept = ?, semester = ?, coursetype = ?, coursecode = ?, coursename = ?,
+			coursenature = ?, facultyid = ?, regulation = ?, degree = ?, academicyear = ?,
+			hodapproval = ?, status = ?
+		WHERE id = ?`,
+		snapshot.Dept, snapshot.Semester, snapshot.CourseType, snapshot.CourseCode, snapshot.CourseName,
+		snapshot.CourseNature, snapshot.FacultyID, snapshot.Regulation, snapshot.Degree, snapshot.AcademicYear,
+		snapshot.HodApproval, snapshot.Status, id,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply reverted snapshot: " + err.Error()})
+		return
+	}
+
+	if err := db.RecordAudit(tx, "revert", id, actorID(c), current, snapshot); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record audit entry: " + err.Error()})
+		return
+	}
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to re-synchronize course details", "error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit transaction: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Course reverted successfully"})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"course-upload/go_server/auth"
+	"course-upload/go_server/db"
+	"course-upload/go_server/models"
+)
+
+// scopeFilter returns the column/value a non-admin user's queries must be
+// restricted to, mirroring the dept/facultyid visibility rules used
+// elsewhere for per-department scoping. Admins get no restriction.
+func scopeFilter(user *auth.User) (column string, value string, restricted bool) {
+	switch user.Role {
+	case auth.RoleAdmin:
+		return "", "", false
+	case auth.RoleHOD:
+		return "dept", user.Dept, true
+	default: // student, faculty
+		return "facultyid", user.FacultyID, true
+	}
+}
+
+// courseInScope reports whether the course with the given id falls within
+// the non-admin user's dept/facultyid scope. Admins are always in scope.
+func courseInScope(id int, user *auth.User) (bool, error) {
+	column, value, restricted := scopeFilter(user)
+	if !restricted {
+		return true, nil
+	}
+	var actual string
+	err := db.DB.QueryRow(fmt.Sprintf("SELECT %s FROM courses WHERE id = ?", column), id).Scan(&actual)
+	if err != nil {
+		return false, err
+	}
+	return actual == value, nil
+}
+
+// courseVisibleTo reports whether course falls within the non-admin user's
+// dept/facultyid scope. It's the in-memory equivalent of courseInScope for
+// events (e.g. websocket broadcasts) that aren't tied to a single row id
+// already resolved against the database.
+func courseVisibleTo(course models.Course, user *auth.User) bool {
+	column, value, restricted := scopeFilter(user)
+	if !restricted {
+		return true
+	}
+	switch column {
+	case "dept":
+		return course.Dept == value
+	default: // facultyid
+		return course.FacultyID == value
+	}
+}
+
+// actorID returns the authenticated user's ID for attributing an audit
+// entry, or 0 if the request carries no authenticated user.
+func actorID(c *gin.Context) int {
+	if user, ok := auth.UserFromContext(c); ok {
+		return user.ID
+	}
+	return 0
+}
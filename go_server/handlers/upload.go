@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+
+	"course-upload/go_server/db"
+	"course-upload/go_server/models"
+)
+
+// ingestCourseRow validates and normalizes course, then upserts it and
+// records an audit entry: the per-row step shared by UploadCoursesJSON and
+// UploadCoursesFile. row is the 1-indexed row/line number used to label any
+// reported errors. On success it returns the course to publish (with its ID
+// populated); courseID 0 after a successful upsert (no audit subject) yields
+// a nil course with no error.
+func ingestCourseRow(tx *sql.Tx, stmt *sql.Stmt, row int, course models.Course, actor int) (published *models.Course, rowErrs []models.RowError) {
+	if fieldErrs := models.ValidateCourse(course); len(fieldErrs) > 0 {
+		for _, fe := range fieldErrs {
+			rowErrs = append(rowErrs, models.RowError{Row: row, FieldError: fe})
+		}
+		return nil, rowErrs
+	}
+	course.CourseNature = models.NormalizeCourseNature(course.CourseNature)
+
+	existing, err := db.FetchCourseByNaturalKey(tx, course)
+	action := "create"
+	before := models.Course{}
+	if err == nil {
+		action = "update"
+		before = existing
+	} else if err != sql.ErrNoRows {
+		log.Printf("Error checking for existing course %s: %v", course.CourseCode, err)
+		rowErrs = append(rowErrs, models.RowError{Row: row, FieldError: models.FieldError{Message: err.Error()}})
+		return nil, rowErrs
+	}
+
+	res, err := stmt.Exec(
+		course.Dept, course.Semester, course.CourseType, course.CourseCode,
+		course.CourseName, course.CourseNature, course.FacultyID, course.Regulation,
+		course.Degree, course.AcademicYear, course.HodApproval, course.Status,
+	)
+	if err != nil {
+		log.Printf("Error processing course %s: %v", course.CourseCode, err)
+		rowErrs = append(rowErrs, models.RowError{Row: row, FieldError: models.FieldError{Message: err.Error()}})
+		return nil, rowErrs
+	}
+	courseID := existing.ID
+	if action == "create" {
+		if insertedID, err := res.LastInsertId(); err == nil {
+			courseID = int(insertedID)
+		}
+	}
+	if courseID == 0 {
+		return nil, nil
+	}
+
+	// UpsertCourseQuery's ON DUPLICATE KEY UPDATE doesn't touch every column
+	// (e.g. coursetype/coursenature survive an update unchanged), so course
+	// may no longer match the persisted row. Re-fetch it so the audit entry
+	// and published event reflect what was actually written, the same way
+	// UpdateCourse/DeleteCourse re-fetch after their own mutations.
+	persisted, err := db.FetchCourseByID(tx, courseID)
+	if err != nil {
+		log.Printf("Error loading persisted course %s: %v", course.CourseCode, err)
+		rowErrs = append(rowErrs, models.RowError{Row: row, FieldError: models.FieldError{Message: err.Error()}})
+		return nil, rowErrs
+	}
+
+	if err := db.RecordAudit(tx, action, courseID, actor, before, persisted); err != nil {
+		log.Printf("Error recording audit for course %s: %v", course.CourseCode, err)
+	}
+	return &persisted, nil
+}
+
+// UploadCoursesJSON godoc
+//
+//	@Summary		Bulk upload courses (JSON)
+//	@Description	Inserts a batch of courses from a JSON payload, validating each row and reporting per-row errors instead of aborting.
+//	@Tags			courses
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		[]models.CourseCreateRequest	true	"Courses to upload"
+//	@Success		200		{object}	map[string]interface{}
+//	@Router			/api/upload-courses-json [post]
+func UploadCoursesJSON(c *gin.Context) {
+	var requests []models.CourseCreateRequest
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid JSON data provided", "error": err.Error()})
+		return
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to start database transaction", "error": err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(db.UpsertCourseQuery)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to prepare SQL statement", "error": err.Error()})
+		return
+	}
+	defer stmt.Close()
+
+	var rowErrors []models.RowError
+	var publishedCourses []models.Course
+	successCount := 0
+	for i, req := range requests {
+		course := req.ToCourse()
+		published, rowErrs := ingestCourseRow(tx, stmt, i+1, course, actorID(c))
+		if len(rowErrs) > 0 {
+			rowErrors = append(rowErrors, rowErrs...)
+			continue
+		}
+		if published != nil {
+			publishedCourses = append(publishedCourses, *published)
+		}
+		successCount++
+	}
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to synchronize course details", "error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to commit database transaction", "error": err.Error()})
+		return
+	}
+
+	for _, course := range publishedCourses {
+		eventBus.Publish(models.CourseEvent{Type: "created", Course: course})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Courses uploaded successfully.",
+		"success_count": successCount,
+		"errors":        rowErrors,
+	})
+}
+
+// courseFileColumns maps the Course fields expected in an uploaded CSV/XLSX
+// file to their header names.
+var courseFileColumns = []string{
+	"dept", "semester", "coursetype", "coursecode", "coursename",
+	"coursenature", "facultyid", "regulation", "degree", "academicyear", "hodapproval",
+}
+
+// readRowsFromFile dispatches to the CSV or XLSX reader based on the
+// uploaded file's extension.
+func readRowsFromFile(fileHeader *multipart.FileHeader) ([][]string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		return reader.ReadAll()
+	case ".xlsx":
+		wb, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer wb.Close()
+		sheet := wb.GetSheetName(0)
+		return wb.GetRows(sheet)
+	default:
+		return nil, fmt.Errorf("unsupported file extension %q, expected .csv or .xlsx", filepath.Ext(fileHeader.Filename))
+	}
+}
+
+// courseFromRow builds a Course from a data row using the column positions
+// discovered in the file's header. missingColumn is non-empty if a required
+// column wasn't present in the header at all.
+func courseFromRow(record []string, columnIndex map[string]int) (course models.Course, missingColumn string) {
+	field := func(name string) string {
+		idx, ok := columnIndex[name]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	for _, name := range courseFileColumns {
+		if _, ok := columnIndex[name]; !ok {
+			return models.Course{}, name
+		}
+	}
+
+	course = models.Course{
+		Dept:         field("dept"),
+		Semester:     field("semester"),
+		CourseType:   field("coursetype"),
+		CourseCode:   field("coursecode"),
+		CourseName:   field("coursename"),
+		CourseNature: field("coursenature"),
+		FacultyID:    field("facultyid"),
+		Regulation:   field("regulation"),
+		Degree:       field("degree"),
+		AcademicYear: field("academicyear"),
+		HodApproval:  field("hodapproval"),
+	}
+	return course, ""
+}
+
+// UploadCoursesFile godoc
+//
+//	@Summary		Bulk upload courses (CSV/XLSX)
+//	@Description	Ingests a CSV or XLSX file through the same upsert used by UploadCoursesJSON, validating and reporting per-row errors (line number, offending column, reason) instead of aborting.
+//	@Tags			courses
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"CSV or XLSX file"
+//	@Success		200		{object}	map[string]interface{}
+//	@Router			/api/upload-courses-file [post]
+func UploadCoursesFile(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "No file uploaded", "error": err.Error()})
+		return
+	}
+
+	rows, err := readRowsFromFile(fileHeader)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to read uploaded file", "error": err.Error()})
+		return
+	}
+	if len(rows) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "File must contain a header row and at least one data row"})
+		return
+	}
+
+	columnIndex := make(map[string]int, len(rows[0]))
+	for i, col := range rows[0] {
+		columnIndex[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	tx, err := db.DB.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to start database transaction", "error": err.Error()})
+		return
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(db.UpsertCourseQuery)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to prepare SQL statement", "error": err.Error()})
+		return
+	}
+	defer stmt.Close()
+
+	var rowErrors []models.RowError
+	var publishedCourses []models.Course
+	successCount := 0
+	for i, record := range rows[1:] {
+		lineNumber := i + 2 // account for the header row, 1-indexed
+
+		course, missingColumn := courseFromRow(record, columnIndex)
+		if missingColumn != "" {
+			rowErrors = append(rowErrors, models.RowError{Row: lineNumber, FieldError: models.FieldError{
+				Field: missingColumn, Message: "column is missing from the file header",
+			}})
+			continue
+		}
+		course.Status = 1
+
+		published, rowErrs := ingestCourseRow(tx, stmt, lineNumber, course, actorID(c))
+		if len(rowErrs) > 0 {
+			rowErrors = append(rowErrors, rowErrs...)
+			continue
+		}
+		if published != nil {
+			publishedCourses = append(publishedCourses, *published)
+		}
+		successCount++
+	}
+
+	if err := db.SyncCourseDetails(tx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to synchronize course details", "error": err.Error()})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to commit database transaction", "error": err.Error()})
+		return
+	}
+
+	for _, course := range publishedCourses {
+		eventBus.Publish(models.CourseEvent{Type: "created", Course: course})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "File processed.",
+		"success_count": successCount,
+		"errors":        rowErrors,
+	})
+}
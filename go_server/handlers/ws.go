@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"course-upload/go_server/auth"
+	"course-upload/go_server/models"
+)
+
+const (
+	wsPingPeriod = 30 * time.Second
+	wsPongWait   = 60 * time.Second
+)
+
+// EventBus decouples course handlers from the transport that delivers live
+// updates to clients, so UploadCoursesJSON/UpdateCourse/DeleteCourse only
+// need to know how to publish an event, not who's listening.
+type EventBus interface {
+	Publish(event models.CourseEvent)
+}
+
+// wsClient pairs a registered connection with the user that authenticated
+// it, so Run can scope each broadcast to what that user is allowed to see.
+type wsClient struct {
+	conn *websocket.Conn
+	user *auth.User
+}
+
+// Hub tracks connected /api/ws/courses clients and fans out CourseEvents to
+// the ones whose dept/facultyid scope covers the event's course. All
+// writes to a websocket.Conn happen on the Run goroutine so a connection is
+// never written to concurrently; reads happen on the ServeCourseWS goroutine
+// that owns the connection.
+type Hub struct {
+	mu         sync.Mutex
+	clients    map[*websocket.Conn]*auth.User
+	register   chan wsClient
+	unregister chan *websocket.Conn
+	broadcast  chan models.CourseEvent
+}
+
+// newHub creates an empty Hub. Call Run in a goroutine to start it.
+func newHub() *Hub {
+	return &Hub{
+		clients:    make(map[*websocket.Conn]*auth.User),
+		register:   make(chan wsClient),
+		unregister: make(chan *websocket.Conn),
+		broadcast:  make(chan models.CourseEvent),
+	}
+}
+
+// Run processes registrations, broadcasts, and the heartbeat ping until the
+// process exits. It is meant to run for the lifetime of the server.
+func (h *Hub) Run() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	drop := func(conn *websocket.Conn) {
+		conn.Close()
+		delete(h.clients, conn)
+	}
+
+	for {
+		select {
+		case client := <-h.register:
+			h.mu.Lock()
+			h.clients[client.conn] = client.user
+			h.mu.Unlock()
+		case conn := <-h.unregister:
+			h.mu.Lock()
+			if _, ok := h.clients[conn]; ok {
+				drop(conn)
+			}
+			h.mu.Unlock()
+		case event := <-h.broadcast:
+			h.mu.Lock()
+			for conn, user := range h.clients {
+				if !courseVisibleTo(event.Course, user) {
+					continue
+				}
+				if err := conn.WriteJSON(event); err != nil {
+					drop(conn)
+				}
+			}
+			h.mu.Unlock()
+		case <-ticker.C:
+			h.mu.Lock()
+			for conn := range h.clients {
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					drop(conn)
+				}
+			}
+			h.mu.Unlock()
+		}
+	}
+}
+
+// Publish implements EventBus by handing the event to the Run goroutine.
+func (h *Hub) Publish(event models.CourseEvent) {
+	h.broadcast <- event
+}
+
+// CourseHub is the process-wide hub of connected dashboard clients. Call
+// CourseHub.Run in a goroutine during startup.
+var CourseHub = newHub()
+
+// eventBus is what course handlers publish to; tests can swap this for a fake.
+var eventBus EventBus = CourseHub
+
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return r.Header.Get("Origin") == "http://localhost:3000"
+	},
+}
+
+// ServeCourseWS godoc
+//
+//	@Summary		Live course change feed
+//	@Description	Upgrades the connection to a websocket and streams {type, course} events whenever a course is created, updated, or deleted, scoped to the connecting user's dept/facultyid the same way GetCourses is. Authenticates via a "token" query param (auth.WSMiddleware) since browsers can't set an Authorization header on the handshake.
+//	@Tags			courses
+//	@Param			token	query	string	true	"Access token"
+//	@Router			/api/ws/courses [get]
+func ServeCourseWS(c *gin.Context) {
+	user, ok := auth.UserFromContext(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	CourseHub.register <- wsClient{conn: conn, user: user}
+	defer func() {
+		CourseHub.unregister <- conn
+	}()
+
+	// Block on reads purely to detect client disconnects and keep pong deadlines fresh;
+	// the hub itself never expects incoming messages.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package models holds the data shapes shared across the handlers, db, and
+// router packages: the Course domain struct, its request/response DTOs, and
+// the field-level validation rules every ingestion path relies on.
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Course defines the data structure for a course with JSON tags for binding.
+type Course struct {
+	ID           int    `json:"id"`
+	Dept         string `json:"dept"`
+	Semester     string `json:"semester"`
+	CourseType   string `json:"coursetype"`
+	CourseCode   string `json:"coursecode"`
+	CourseName   string `json:"coursename"`
+	CourseNature string `json:"coursenature"`
+	FacultyID    string `json:"facultyid"`
+	Regulation   string `json:"regulation"`
+	Degree       string `json:"degree"`
+	AcademicYear string `json:"academicyear"`
+	HodApproval  string `json:"hodapproval"`
+	Status       int    `json:"status"`
+}
+
+// CourseCreateRequest is the body accepted by the course-creation endpoints.
+// Unlike Course, required fields are enforced at binding time.
+type CourseCreateRequest struct {
+	Dept         string `json:"dept" binding:"required"`
+	Semester     string `json:"semester" binding:"required"`
+	CourseType   string `json:"coursetype"`
+	CourseCode   string `json:"coursecode" binding:"required"`
+	CourseName   string `json:"coursename" binding:"required"`
+	CourseNature string `json:"coursenature" binding:"required"`
+	FacultyID    string `json:"facultyid"`
+	Regulation   string `json:"regulation" binding:"required"`
+	Degree       string `json:"degree" binding:"required"`
+	AcademicYear string `json:"academicyear" binding:"required"`
+	HodApproval  string `json:"hodapproval"`
+}
+
+// ToCourse builds the Course that a CourseCreateRequest describes, active by default.
+func (r CourseCreateRequest) ToCourse() Course {
+	return Course{
+		Dept:         r.Dept,
+		Semester:     r.Semester,
+		CourseType:   r.CourseType,
+		CourseCode:   r.CourseCode,
+		CourseName:   r.CourseName,
+		CourseNature: r.CourseNature,
+		FacultyID:    r.FacultyID,
+		Regulation:   r.Regulation,
+		Degree:       r.Degree,
+		AcademicYear: r.AcademicYear,
+		HodApproval:  r.HodApproval,
+		Status:       1,
+	}
+}
+
+// CourseUpdateRequest is the body accepted by PUT /api/courses/:id.
+type CourseUpdateRequest struct {
+	CourseCode   string `json:"coursecode" binding:"required"`
+	CourseName   string `json:"coursename" binding:"required"`
+	Dept         string `json:"dept" binding:"required"`
+	CourseNature string `json:"coursenature" binding:"required"`
+	FacultyID    string `json:"facultyid"`
+	HodApproval  string `json:"hodapproval"`
+	CourseType   string `json:"coursetype"`
+	Semester     string `json:"semester" binding:"required"`
+	Regulation   string `json:"regulation" binding:"required"`
+	Degree       string `json:"degree" binding:"required"`
+	AcademicYear string `json:"academicyear" binding:"required"`
+}
+
+// ToCourse builds the Course that a CourseUpdateRequest describes for the given id.
+func (r CourseUpdateRequest) ToCourse(id int) Course {
+	return Course{
+		ID:           id,
+		Dept:         r.Dept,
+		Semester:     r.Semester,
+		CourseType:   r.CourseType,
+		CourseCode:   r.CourseCode,
+		CourseName:   r.CourseName,
+		CourseNature: r.CourseNature,
+		FacultyID:    r.FacultyID,
+		Regulation:   r.Regulation,
+		Degree:       r.Degree,
+		AcademicYear: r.AcademicYear,
+		HodApproval:  r.HodApproval,
+	}
+}
+
+// CourseResponse is what the API returns for a course, kept distinct from
+// Course so the wire format can evolve independently of the storage shape.
+type CourseResponse struct {
+	ID           int    `json:"id"`
+	Dept         string `json:"dept"`
+	Semester     string `json:"semester"`
+	CourseType   string `json:"coursetype"`
+	CourseCode   string `json:"coursecode"`
+	CourseName   string `json:"coursename"`
+	CourseNature string `json:"coursenature"`
+	FacultyID    string `json:"facultyid"`
+	Regulation   string `json:"regulation"`
+	Degree       string `json:"degree"`
+	AcademicYear string `json:"academicyear"`
+	HodApproval  string `json:"hodapproval"`
+	Status       int    `json:"status"`
+}
+
+// NewCourseResponse converts a stored Course to the API's response DTO.
+func NewCourseResponse(c Course) CourseResponse {
+	return CourseResponse(c)
+}
+
+// FieldError describes a single invalid field on a Course.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RowError is a FieldError attributed to a specific input row, returned by
+// the bulk upload endpoints so callers can fix their source file.
+type RowError struct {
+	Row int `json:"row"`
+	FieldError
+}
+
+// CourseEvent is broadcast to connected clients whenever a course mutation commits.
+type CourseEvent struct {
+	Type   string `json:"type"` // "created", "updated", or "deleted"
+	Course Course `json:"course"`
+}
+
+// CourseAuditEntry is a single recorded change to a course, as returned by
+// the course history endpoint.
+type CourseAuditEntry struct {
+	ID        int       `json:"id"`
+	CourseID  int       `json:"course_id"`
+	Action    string    `json:"action"`
+	ActorID   int       `json:"actor_id"`
+	Before    Course    `json:"before"`
+	After     Course    `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// allowedHodApprovals are the canonical values accepted for Course.HodApproval.
+var allowedHodApprovals = map[string]bool{"approved": true, "pending": true, "rejected": true}
+
+// NormalizeCourseNature maps free-form coursenature input to the canonical
+// values used by the course_details sync CASE expression, or "" if the
+// value doesn't match any recognized nature.
+func NormalizeCourseNature(nature string) string {
+	switch strings.ToLower(strings.TrimSpace(nature)) {
+	case "theory & lab", "theory with lab", "theory and lab":
+		return "Theory & Lab"
+	case "theory":
+		return "Theory"
+	case "lab":
+		return "Lab"
+	default:
+		return ""
+	}
+}
+
+// ValidateCourse applies field-level rules shared by every course-ingestion
+// endpoint and returns the list of problems found, if any.
+func ValidateCourse(course Course) []FieldError {
+	var errs []FieldError
+
+	if course.CourseCode == "" {
+		errs = append(errs, FieldError{Field: "coursecode", Message: "coursecode is required"})
+	}
+	if course.CourseName == "" {
+		errs = append(errs, FieldError{Field: "coursename", Message: "coursename is required"})
+	}
+
+	if semester, err := strconv.Atoi(course.Semester); err != nil || semester < 1 || semester > 8 {
+		errs = append(errs, FieldError{Field: "semester", Message: "semester must be an integer between 1 and 8"})
+	}
+
+	if !allowedHodApprovals[strings.ToLower(course.HodApproval)] {
+		errs = append(errs, FieldError{Field: "hodapproval", Message: "hodapproval must be one of: approved, pending, rejected"})
+	}
+
+	if NormalizeCourseNature(course.CourseNature) == "" {
+		errs = append(errs, FieldError{Field: "coursenature", Message: "coursenature must be one of: theory, lab, theory & lab"})
+	}
+
+	return errs
+}
@@ -0,0 +1,79 @@
+package models
+
+import "testing"
+
+func validCourse() Course {
+	return Course{
+		CourseCode:   "CS101",
+		CourseName:   "Intro to CS",
+		Semester:     "1",
+		HodApproval:  "approved",
+		CourseNature: "theory",
+	}
+}
+
+func TestValidateCourseAcceptsValidCourse(t *testing.T) {
+	if errs := ValidateCourse(validCourse()); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateCourseRequiresCourseCodeAndName(t *testing.T) {
+	course := validCourse()
+	course.CourseCode = ""
+	course.CourseName = ""
+
+	errs := ValidateCourse(course)
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	if !fields["coursecode"] || !fields["coursename"] {
+		t.Fatalf("expected coursecode and coursename errors, got %v", errs)
+	}
+}
+
+func TestValidateCourseRejectsSemesterOutOfRange(t *testing.T) {
+	for _, semester := range []string{"0", "9", "abc"} {
+		course := validCourse()
+		course.Semester = semester
+		errs := ValidateCourse(course)
+		if len(errs) == 0 {
+			t.Fatalf("semester %q: expected an error, got none", semester)
+		}
+	}
+}
+
+func TestValidateCourseRejectsUnknownHodApproval(t *testing.T) {
+	course := validCourse()
+	course.HodApproval = "maybe"
+	if errs := ValidateCourse(course); len(errs) == 0 {
+		t.Fatal("expected an error for an unrecognized hodapproval value")
+	}
+}
+
+func TestValidateCourseRejectsUnrecognizedCourseNature(t *testing.T) {
+	course := validCourse()
+	course.CourseNature = "seminar"
+	if errs := ValidateCourse(course); len(errs) == 0 {
+		t.Fatal("expected an error for an unrecognized coursenature value")
+	}
+}
+
+func TestNormalizeCourseNature(t *testing.T) {
+	cases := map[string]string{
+		"theory":          "Theory",
+		"  Theory  ":      "Theory",
+		"LAB":             "Lab",
+		"Theory & Lab":    "Theory & Lab",
+		"theory with lab": "Theory & Lab",
+		"theory and lab":  "Theory & Lab",
+		"unrecognized":    "",
+		"":                "",
+	}
+	for in, want := range cases {
+		if got := NormalizeCourseNature(in); got != want {
+			t.Errorf("NormalizeCourseNature(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
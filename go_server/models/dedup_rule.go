@@ -0,0 +1,33 @@
+package models
+
+// DedupRule configures how course_details sync ranks courses within a
+// coursecode/semester/regulation/degree/academicyear group: among the
+// courses in a group, the one matching the rule with the lowest Priority
+// wins. Regulation and Degree may be "*" to match any value.
+type DedupRule struct {
+	ID            int    `json:"id"`
+	Regulation    string `json:"regulation"`
+	Degree        string `json:"degree"`
+	NaturePattern string `json:"nature_pattern"`
+	Priority      int    `json:"priority"`
+}
+
+// DedupRuleRequest is one entry of the body accepted by PUT /api/admin/dedup-rules.
+// Priority has no "required" tag: 0 is the highest (and a perfectly valid)
+// priority, and Gin's required check would reject it as the int zero-value.
+type DedupRuleRequest struct {
+	Regulation    string `json:"regulation" binding:"required"`
+	Degree        string `json:"degree" binding:"required"`
+	NaturePattern string `json:"nature_pattern" binding:"required"`
+	Priority      int    `json:"priority"`
+}
+
+// ToDedupRule builds the DedupRule a DedupRuleRequest describes.
+func (r DedupRuleRequest) ToDedupRule() DedupRule {
+	return DedupRule{
+		Regulation:    r.Regulation,
+		Degree:        r.Degree,
+		NaturePattern: r.NaturePattern,
+		Priority:      r.Priority,
+	}
+}
@@ -0,0 +1,78 @@
+// Package router assembles the Gin engine: logging/CORS middleware, the
+// public auth routes, the JWT-protected /api routes, and the Swagger UI.
+package router
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"course-upload/go_server/auth"
+	_ "course-upload/go_server/docs"
+	"course-upload/go_server/handlers"
+)
+
+// New builds the fully-routed Gin engine. jwtSecret must match the secret
+// passed to handlers.SetJWTSecret.
+func New(jwtSecret []byte) *gin.Engine {
+	r := gin.Default()
+
+	r.Use(gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		return fmt.Sprintf("%s - [%s] \"%s %s %s %d %s \"%s\" %s\"\n",
+			param.ClientIP, param.TimeStamp.Format("2006-01-02 15:04:05"),
+			param.Method, param.Path, param.Request.Proto,
+			param.StatusCode, param.Latency, param.Request.UserAgent(), param.ErrorMessage)
+	}))
+	r.Use(gin.Recovery())
+
+	// CORS Middleware
+	r.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Accept, Origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+		c.Next()
+	})
+
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Login and refresh are public; everything else under /api requires a valid JWT.
+	r.POST("/api/login", handlers.Login)
+	r.POST("/api/refresh", handlers.Refresh)
+
+	// The websocket handshake can't carry an Authorization header (browsers'
+	// WebSocket API doesn't support custom headers), so it authenticates via
+	// a "token" query param instead of the bearer-header Middleware below.
+	r.GET("/api/ws/courses", auth.WSMiddleware(jwtSecret), handlers.ServeCourseWS)
+
+	api := r.Group("/api")
+	api.Use(auth.Middleware(jwtSecret))
+	{
+		// Students may read courses but never mutate them; mutation is
+		// reserved for faculty/HOD/admin.
+		mutate := auth.RequireRole(auth.RoleFaculty, auth.RoleHOD, auth.RoleAdmin)
+		api.POST("/upload-courses-json", mutate, handlers.UploadCoursesJSON)
+		api.POST("/upload-courses-file", mutate, handlers.UploadCoursesFile)
+		api.GET("/courses", handlers.GetCourses)
+		api.PUT("/courses/:id", mutate, handlers.UpdateCourse)
+		api.DELETE("/courses/:id", mutate, handlers.DeleteCourse)
+		api.GET("/courses/:id/history", handlers.GetCourseHistory)
+		api.POST("/courses/:id/revert/:auditId", mutate, handlers.RevertCourse)
+
+		admin := api.Group("/admin")
+		admin.Use(auth.RequireRole(auth.RoleAdmin))
+		{
+			admin.GET("/dedup-rules", handlers.GetDedupRules)
+			admin.PUT("/dedup-rules", handlers.UpdateDedupRules)
+			admin.POST("/resync", handlers.ResyncCourseDetails)
+		}
+	}
+
+	return r
+}